@@ -0,0 +1,172 @@
+// Package auth provides password hashing, JWT issuing/parsing, and the
+// request-context plumbing used to authenticate and authorize API calls.
+package auth
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Role is the access level granted to an authenticated user.
+type Role string
+
+const (
+	RoleAdmin      Role = "admin"
+	RoleInstructor Role = "instructor"
+	RoleStudent    Role = "student"
+)
+
+// User is the authenticated identity attached to a request context.
+type User struct {
+	ID        int
+	Email     string
+	Role      Role
+	StudentID *int // set when Role == RoleStudent; links to the student's own record
+}
+
+type contextKey string
+
+const userContextKey contextKey = "auth.user"
+
+// WithUser returns a copy of ctx carrying the authenticated user.
+func WithUser(ctx context.Context, u *User) context.Context {
+	return context.WithValue(ctx, userContextKey, u)
+}
+
+// UserFromContext returns the authenticated user injected by Middleware, if any.
+func UserFromContext(ctx context.Context) (*User, bool) {
+	u, ok := ctx.Value(userContextKey).(*User)
+	return u, ok
+}
+
+// claims is the JWT payload issued on login.
+type claims struct {
+	Email     string `json:"email"`
+	Role      Role   `json:"role"`
+	StudentID *int   `json:"student_id,omitempty"`
+	jwt.RegisteredClaims
+}
+
+func jwtSecret() []byte {
+	if s := os.Getenv("JWT_SECRET"); s != "" {
+		return []byte(s)
+	}
+	// Fallback for local development only; production must set JWT_SECRET.
+	return []byte("dev-only-insecure-secret-change-me")
+}
+
+const tokenTTL = 24 * time.Hour
+
+// HashPassword bcrypt-hashes a plaintext password for storage.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// CheckPassword compares a bcrypt hash against a plaintext password.
+func CheckPassword(hash, password string) error {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+}
+
+// GenerateToken issues a signed HS256 JWT for the given user.
+func GenerateToken(userID int, email string, role Role, studentID *int) (string, error) {
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		Email:     email,
+		Role:      role,
+		StudentID: studentID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.Itoa(userID),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(tokenTTL)),
+		},
+	})
+	return token.SignedString(jwtSecret())
+}
+
+var errInvalidToken = errors.New("auth: invalid or expired token")
+
+// parseToken validates a token's signature and expiry and returns the User
+// described by its claims.
+func parseToken(tokenString string) (*User, error) {
+	parsed, err := jwt.ParseWithClaims(tokenString, &claims{}, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errInvalidToken
+		}
+		return jwtSecret(), nil
+	})
+	if err != nil || !parsed.Valid {
+		return nil, errInvalidToken
+	}
+	c, ok := parsed.Claims.(*claims)
+	if !ok {
+		return nil, errInvalidToken
+	}
+	userID, err := strconv.Atoi(c.Subject)
+	if err != nil {
+		return nil, errInvalidToken
+	}
+	return &User{ID: userID, Email: c.Email, Role: c.Role, StudentID: c.StudentID}, nil
+}
+
+// blocklist tracks logged-out tokens so they're rejected even before they expire.
+type blocklist struct {
+	mu     sync.RWMutex
+	tokens map[string]time.Time // token -> expiry passed to Invalidate, for periodic cleanup
+}
+
+var blocked = &blocklist{tokens: make(map[string]time.Time)}
+
+// reapInterval controls how often expired entries are swept from the
+// blocklist; it only needs to be frequent relative to tokenTTL, not to
+// wall-clock time.
+const reapInterval = 10 * time.Minute
+
+func init() {
+	go blocked.reapLoop()
+}
+
+// reapLoop periodically removes blocklist entries whose expiry has passed,
+// so a long-running server doesn't accumulate logged-out tokens forever.
+func (b *blocklist) reapLoop() {
+	ticker := time.NewTicker(reapInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		b.reap(time.Now())
+	}
+}
+
+func (b *blocklist) reap(now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for token, expiresAt := range b.tokens {
+		if now.After(expiresAt) {
+			delete(b.tokens, token)
+		}
+	}
+}
+
+// Invalidate adds a token to the server-side blocklist.
+func Invalidate(tokenString string, expiresAt time.Time) {
+	blocked.mu.Lock()
+	defer blocked.mu.Unlock()
+	blocked.tokens[tokenString] = expiresAt
+}
+
+// isBlocked reports whether a token was logged out.
+func isBlocked(tokenString string) bool {
+	blocked.mu.RLock()
+	defer blocked.mu.RUnlock()
+	_, found := blocked.tokens[tokenString]
+	return found
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Authenticate extracts and validates the bearer token carried by a request,
+// checking the Authorization header first and falling back to a "token"
+// query parameter for transports (like a browser WebSocket upgrade) that
+// can't set custom headers.
+func Authenticate(r *http.Request) (*User, error) {
+	tokenString := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if tokenString == "" {
+		tokenString = r.URL.Query().Get("token")
+	}
+	if tokenString == "" {
+		return nil, errInvalidToken
+	}
+	if isBlocked(tokenString) {
+		return nil, errInvalidToken
+	}
+	return parseToken(tokenString)
+}
+
+// Middleware authenticates the request and injects the resulting User into
+// the request context before calling next. OPTIONS requests are passed to
+// next without authentication; any other request without a valid token is
+// rejected with 401 and never reaches next.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		user, err := Authenticate(r)
+		if err != nil {
+			http.Error(w, "Missing or invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(WithUser(r.Context(), user)))
+	})
+}
+
+// RequireRoles builds middleware that only calls the next handler if the
+// context's user holds one of the given roles. Middleware must run first so
+// a *User is in context.
+func RequireRoles(roles ...Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, ok := UserFromContext(r.Context())
+			if !ok {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			for _, role := range roles {
+				if user.Role == role {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			http.Error(w, "Forbidden", http.StatusForbidden)
+		})
+	}
+}
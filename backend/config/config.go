@@ -0,0 +1,89 @@
+// Package config loads server and database settings from environment
+// variables, with sensible local-development defaults.
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds every tunable the server needs to start up.
+type Config struct {
+	DBHost     string
+	DBUser     string
+	DBPassword string
+	DBName     string
+
+	HTTPAddr     string
+	CORSOrigins  []string
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+
+	DBMaxOpenConns int
+	DBMaxIdleConns int
+
+	LogLevel string
+}
+
+// Load reads configuration from the environment, falling back to
+// development defaults for anything unset.
+func Load() Config {
+	return Config{
+		DBHost:     envOr("DB_HOST", "localhost"),
+		DBUser:     envOr("DB_USER", "postgres"),
+		DBPassword: envOr("DB_PASSWORD", "ljeng"),
+		DBName:     envOr("DB_NAME", "Student_management_system"),
+
+		HTTPAddr:     envOr("HTTP_ADDR", ":8080"),
+		CORSOrigins:  strings.Split(envOr("CORS_ORIGINS", "*"), ","),
+		ReadTimeout:  envDuration("READ_TIMEOUT", 10*time.Second),
+		WriteTimeout: envDuration("WRITE_TIMEOUT", 10*time.Second),
+		IdleTimeout:  envDuration("IDLE_TIMEOUT", 60*time.Second),
+
+		DBMaxOpenConns: envInt("DB_MAX_OPEN_CONNS", 25),
+		DBMaxIdleConns: envInt("DB_MAX_IDLE_CONNS", 25),
+
+		LogLevel: envOr("LOG_LEVEL", "info"),
+	}
+}
+
+// ConnString builds the lib/pq connection string for this config.
+func (c Config) ConnString() string {
+	return fmt.Sprintf("user=%s password=%s dbname=%s host=%s sslmode=disable",
+		c.DBUser, c.DBPassword, c.DBName, c.DBHost)
+}
+
+func envOr(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func envInt(key string, fallback int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	parsed, err := strconv.Atoi(v)
+	if err != nil {
+		return fallback
+	}
+	return parsed
+}
+
+func envDuration(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	parsed, err := time.ParseDuration(v)
+	if err != nil {
+		return fallback
+	}
+	return parsed
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/Abhipatel2704/Basic_StudentManagement_goNext/backend/store"
+)
+
+const exportDateLayout = "2006-01-02"
+
+const importExportSheet = "Students" // worksheet name written by xlsx export; import reads the first sheet whatever its name
+
+var studentExportColumns = []string{"id", "first_name", "last_name", "email", "enrollment_date"}
+
+// importRowError describes why a single row of an import failed.
+type importRowError struct {
+	Row    int    `json:"row"`
+	Reason string `json:"reason"`
+}
+
+// importReport summarizes the outcome of a bulk import.
+type importReport struct {
+	Inserted int              `json:"inserted"`
+	Updated  int              `json:"updated"`
+	Errors   []importRowError `json:"errors"`
+}
+
+// exportStudents: GET /api/students/export?format=csv|xlsx
+func exportStudents(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "csv"
+	}
+
+	rows, err := db.Query("SELECT id, first_name, last_name, email, enrollment_date FROM students ORDER BY id")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	rosterStudents := []store.Student{}
+	for rows.Next() {
+		var s store.Student
+		if err := rows.Scan(&s.ID, &s.FirstName, &s.LastName, &s.Email, &s.EnrollmentDate); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		rosterStudents = append(rosterStudents, s)
+	}
+
+	switch format {
+	case "csv":
+		exportStudentsCSV(w, rosterStudents)
+	case "xlsx":
+		exportStudentsXLSX(w, rosterStudents)
+	default:
+		http.Error(w, "format must be csv or xlsx", http.StatusBadRequest)
+	}
+}
+
+func exportStudentsCSV(w http.ResponseWriter, students []store.Student) {
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", `attachment; filename="students.csv"`)
+
+	writer := csv.NewWriter(w)
+	writer.Write(studentExportColumns)
+	for _, s := range students {
+		writer.Write([]string{
+			fmt.Sprint(s.ID), s.FirstName, s.LastName, s.Email, formatExportDate(s.EnrollmentDate),
+		})
+	}
+	writer.Flush()
+}
+
+func formatExportDate(d store.Date) string {
+	if d.Time.IsZero() {
+		return ""
+	}
+	return d.Time.Format(exportDateLayout)
+}
+
+func exportStudentsXLSX(w http.ResponseWriter, students []store.Student) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	f.SetSheetName(f.GetSheetName(0), importExportSheet)
+	for col, header := range studentExportColumns {
+		cell, _ := excelize.CoordinatesToCellName(col+1, 1)
+		f.SetCellValue(importExportSheet, cell, header)
+	}
+	for i, s := range students {
+		row := i + 2
+		f.SetCellValue(importExportSheet, fmt.Sprintf("A%d", row), s.ID)
+		f.SetCellValue(importExportSheet, fmt.Sprintf("B%d", row), s.FirstName)
+		f.SetCellValue(importExportSheet, fmt.Sprintf("C%d", row), s.LastName)
+		f.SetCellValue(importExportSheet, fmt.Sprintf("D%d", row), s.Email)
+		f.SetCellValue(importExportSheet, fmt.Sprintf("E%d", row), formatExportDate(s.EnrollmentDate))
+	}
+
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	w.Header().Set("Content-Disposition", `attachment; filename="students.xlsx"`)
+	if err := f.Write(w); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// importStudents: POST /api/students/import (multipart/form-data, field "file")
+func importStudents(w http.ResponseWriter, r *http.Request) {
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "Missing file upload", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	var rows [][]string
+	if strings.HasSuffix(strings.ToLower(header.Filename), ".xlsx") {
+		rows, err = readXLSXRows(file)
+	} else {
+		rows, err = readCSVRows(file)
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to parse upload: %v", err), http.StatusBadRequest)
+		return
+	}
+	if len(rows) == 0 {
+		json.NewEncoder(w).Encode(importReport{})
+		return
+	}
+
+	// First row is the header; data starts at row 2 for error reporting.
+	report := importReport{Errors: []importRowError{}}
+
+	tx, err := db.Begin()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer tx.Rollback()
+
+	const upsertQuery = `
+	INSERT INTO students (first_name, last_name, email)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (email) DO UPDATE SET first_name = EXCLUDED.first_name, last_name = EXCLUDED.last_name
+	RETURNING (xmax = 0) AS inserted`
+
+	for i, row := range rows[1:] {
+		rowNum := i + 2
+		if len(row) < 3 {
+			report.Errors = append(report.Errors, importRowError{Row: rowNum, Reason: "expected first_name, last_name, email columns"})
+			continue
+		}
+		s := store.Student{
+			FirstName: strings.TrimSpace(row[0]),
+			LastName:  strings.TrimSpace(row[1]),
+			Email:     strings.TrimSpace(row[2]),
+		}
+		if err := validate.Struct(s); err != nil {
+			report.Errors = append(report.Errors, importRowError{Row: rowNum, Reason: validationReason(err)})
+			continue
+		}
+
+		// Isolate each row in its own savepoint so a statement error (e.g. a
+		// unique violation or a value that violates a column constraint)
+		// only aborts that row, not every row after it or the rows already
+		// committed in this same transaction.
+		if _, err := tx.Exec("SAVEPOINT import_row"); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		var inserted bool
+		if err := tx.QueryRow(upsertQuery, s.FirstName, s.LastName, s.Email).Scan(&inserted); err != nil {
+			if _, rbErr := tx.Exec("ROLLBACK TO SAVEPOINT import_row"); rbErr != nil {
+				http.Error(w, rbErr.Error(), http.StatusInternalServerError)
+				return
+			}
+			report.Errors = append(report.Errors, importRowError{Row: rowNum, Reason: err.Error()})
+			continue
+		}
+		if _, err := tx.Exec("RELEASE SAVEPOINT import_row"); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if inserted {
+			report.Inserted++
+		} else {
+			report.Updated++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(report)
+}
+
+func readCSVRows(f multipartFile) ([][]string, error) {
+	reader := csv.NewReader(f)
+	reader.FieldsPerRecord = -1
+	return reader.ReadAll()
+}
+
+func readXLSXRows(f multipartFile) ([][]string, error) {
+	wb, err := excelize.OpenReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer wb.Close()
+
+	sheet := wb.GetSheetName(0)
+	return wb.GetRows(sheet)
+}
+
+// multipartFile is the subset of multipart.File methods the CSV/XLSX
+// readers need, so both can share the same helper signatures.
+type multipartFile interface {
+	Read(p []byte) (n int, err error)
+	Seek(offset int64, whence int) (int64, error)
+}
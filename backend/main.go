@@ -1,245 +1,516 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	"os/signal"
 	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/cors"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+
+	"github.com/Abhipatel2704/Basic_StudentManagement_goNext/backend/auth"
+	"github.com/Abhipatel2704/Basic_StudentManagement_goNext/backend/config"
+	"github.com/Abhipatel2704/Basic_StudentManagement_goNext/backend/migrations"
+	"github.com/Abhipatel2704/Basic_StudentManagement_goNext/backend/store"
+	"github.com/Abhipatel2704/Basic_StudentManagement_goNext/backend/ws"
 
 	// Driver for PostgreSQL
 	_ "github.com/lib/pq"
 )
 
-// The Go struct that represents a Student record.
-type Student struct {
-	ID             int    `json:"id"`         // Unique ID from the database
-	FirstName      string `json:"first_name"` // Maps to 'first_name' column
-	LastName       string `json:"last_name"`
-	Email          string `json:"email"`
-	EnrollmentDate string `json:"enrollment_date"` // Date the student was added
-}
+// shutdownGracePeriod bounds how long in-flight requests get to finish
+// once a shutdown signal arrives.
+const shutdownGracePeriod = 10 * time.Second
+
+// studentEventsChannel is the Postgres NOTIFY channel roster mutations are
+// published on, so multiple instances of this service stay in sync.
+const studentEventsChannel = "student_events"
 
-var db *sql.DB // Global variable to hold the database connection
+var (
+	db       *sql.DB // Global variable to hold the database connection
+	cfg      config.Config
+	students store.StudentStore
+	validate = validator.New()
+	hub      = ws.NewHub()
+)
 
 func init() {
 	// 1. Database Connection Setup
-	connStr := "user=postgres password=ljeng dbname=Student_management_system host=localhost sslmode=disable"
+	cfg = config.Load()
 	var err error
-	db, err = sql.Open("postgres", connStr)
+	db, err = sql.Open("postgres", cfg.ConnString())
 	if err != nil {
 		log.Fatal(err) // Stops the program if connection configuration fails
 	}
 	if err = db.Ping(); err != nil {
 		log.Fatal(err) // Stops the program if the DB server is unreachable
 	}
+	db.SetMaxOpenConns(cfg.DBMaxOpenConns)
+	db.SetMaxIdleConns(cfg.DBMaxIdleConns)
 	fmt.Println("Successfully connected to PostgreSQL!")
-	createTable()
+
+	runMigrations()
+	students = store.NewPostgresStore(db)
 }
 
-// createTable checks and creates the students table if it doesn't exist.
-func createTable() {
-	const tableCreationQuery = `
-	CREATE TABLE IF NOT EXISTS students (
-		id SERIAL PRIMARY KEY,
-		first_name VARCHAR(100) NOT NULL,
-		last_name VARCHAR(100) NOT NULL,
-		email VARCHAR(100) UNIQUE NOT NULL,
-		enrollment_date DATE DEFAULT CURRENT_DATE
-	);`
-	if _, err := db.Exec(tableCreationQuery); err != nil {
-		log.Fatal("Failed to create table:", err)
+// runMigrations applies every embedded migration up to the latest version.
+func runMigrations() {
+	driver, err := postgres.WithInstance(db, &postgres.Config{})
+	if err != nil {
+		log.Fatal("Failed to init migration driver:", err)
+	}
+	sourceDriver, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		log.Fatal("Failed to load migrations:", err)
+	}
+	m, err := migrate.NewWithInstance("iofs", sourceDriver, "postgres", driver)
+	if err != nil {
+		log.Fatal("Failed to init migrator:", err)
+	}
+	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+		log.Fatal("Failed to run migrations:", err)
 	}
 }
 
 func main() {
-	// 2. Routing Setup
-	http.HandleFunc("/api/students", studentsHandler)     // Handles /api/students (GET all, POST new)
-	http.HandleFunc("/api/students/", studentByIDHandler) // Handles /api/students/{id} (GET one, PUT, DELETE)
+	r := chi.NewRouter()
+
+	// 2. Cross-cutting middleware
+	r.Use(chimiddleware.Recoverer)
+	r.Use(cors.Handler(cors.Options{
+		AllowedOrigins: cfg.CORSOrigins,
+		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedHeaders: []string{"Content-Type", "Authorization"},
+	}))
+	r.Use(jsonContentType)
+
+	// 3. Routing Setup
+	r.Route("/api/students", func(r chi.Router) {
+		r.Use(auth.Middleware)
+
+		// The WebSocket handshake can't set an Authorization header, so its
+		// bearer token rides in the query string instead (see
+		// auth.Authenticate). Keep this route out of chimiddleware.Logger's
+		// group below so that token never ends up in the request logs.
+		r.With(auth.RequireRoles(auth.RoleAdmin, auth.RoleInstructor)).Get("/stream", hub.ServeHTTP)
+
+		r.Group(func(r chi.Router) {
+			r.Use(chimiddleware.Logger)
+
+			// Listing the whole roster is reserved for staff; students fetch
+			// their own record via GET /api/students/{id} instead.
+			r.With(auth.RequireRoles(auth.RoleAdmin, auth.RoleInstructor)).Get("/", listStudents)
+			r.With(auth.RequireRoles(auth.RoleAdmin, auth.RoleInstructor)).Post("/", createStudent)
+
+			r.With(auth.RequireRoles(auth.RoleAdmin, auth.RoleInstructor)).Get("/export", exportStudents)
+			r.With(auth.RequireRoles(auth.RoleAdmin, auth.RoleInstructor)).Post("/import", importStudents)
+
+			r.Route("/{id}", func(r chi.Router) {
+				r.Get("/", getStudent)
+				r.With(auth.RequireRoles(auth.RoleAdmin, auth.RoleInstructor)).Put("/", updateStudent)
+				r.With(auth.RequireRoles(auth.RoleAdmin, auth.RoleInstructor)).Delete("/", deleteStudent)
+			})
+		})
+	})
+
+	// 4. Auth routes
+	r.Group(func(r chi.Router) {
+		r.Use(chimiddleware.Logger)
+		r.Post("/api/auth/login", loginHandler)
+		r.With(auth.Middleware).Post("/api/auth/logout", logoutHandler)
+		r.With(auth.Middleware, auth.RequireRoles(auth.RoleAdmin)).Post("/api/auth/register", registerHandler)
+	})
+
+	go ws.ListenNotify(cfg.ConnString(), studentEventsChannel, hub)
+
+	srv := &http.Server{
+		Addr:         cfg.HTTPAddr,
+		Handler:      r,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
+	}
 
-	fmt.Println("Server listening on port 8080...")
-	// Start the server
-	log.Fatal(http.ListenAndServe(":8080", nil))
-}
+	go func() {
+		fmt.Printf("Server listening on %s...\n", cfg.HTTPAddr)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal(err)
+		}
+	}()
 
-// Handler function for requests without an ID (GET all, POST new)
-func studentsHandler(w http.ResponseWriter, r *http.Request) {
-	// Set CORS headers for the frontend (Next.js) to access this API
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS, PUT, DELETE")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
 
-	if r.Method == "OPTIONS" {
-		w.WriteHeader(http.StatusOK)
-		return
+	fmt.Println("Shutting down...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownGracePeriod)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Println("Error during shutdown:", err)
 	}
+	db.Close()
+}
+
+// jsonContentType sets a JSON response content type on every API route;
+// handlers that respond with something else (the CSV/XLSX export)
+// overwrite it.
+func jsonContentType(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		next.ServeHTTP(w, r)
+	})
+}
 
-	switch r.Method {
-	case "GET":
-		getAllStudents(w, r)
-	case "POST":
-		createStudent(w, r)
+// --- CRUD Handlers ---
+
+// idFromRequest extracts and parses the {id} path parameter.
+func idFromRequest(r *http.Request) (int, error) {
+	return strconv.Atoi(chi.URLParam(r, "id"))
+}
+
+// canAccessStudent reports whether the authenticated request may read the
+// student record with the given id: staff may read any record, a student
+// may only read their own.
+func canAccessStudent(r *http.Request, id int) bool {
+	user, ok := auth.UserFromContext(r.Context())
+	if !ok {
+		return false
+	}
+	switch user.Role {
+	case auth.RoleAdmin, auth.RoleInstructor:
+		return true
+	case auth.RoleStudent:
+		return user.StudentID != nil && *user.StudentID == id
 	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return false
 	}
 }
 
-// Handler function for requests with an ID (GET one, PUT, DELETE)
-func studentByIDHandler(w http.ResponseWriter, r *http.Request) {
-	// Set CORS headers
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS, PUT, DELETE")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+// validationError is the structured 422 body returned when a Student fails
+// struct-tag validation.
+type validationError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
 
-	if r.Method == "OPTIONS" {
-		w.WriteHeader(http.StatusOK)
-		return
+// writeValidationError reports the first failing field of a validator error
+// as a 422 response.
+func writeValidationError(w http.ResponseWriter, err error) bool {
+	fieldErrors, ok := err.(validator.ValidationErrors)
+	if !ok || len(fieldErrors) == 0 {
+		return false
 	}
+	first := fieldErrors[0]
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	json.NewEncoder(w).Encode(validationError{
+		Field:   strings.ToLower(first.Field()),
+		Message: fmt.Sprintf("failed validation on tag %q", first.Tag()),
+	})
+	return true
+}
 
-	// Simple way to extract ID from URL path (e.g., "/api/students/101" -> "101")
-	path := r.URL.Path
-	idStr := path[len("/api/students/"):]
-	id, err := strconv.Atoi(idStr)
-	if err != nil {
-		http.Error(w, "Invalid student ID format", http.StatusBadRequest)
-		return
+// validationReason renders a validate.Struct error as a single-line string
+// for contexts (like the import report) that report per-row reasons rather
+// than a JSON validationError body.
+func validationReason(err error) string {
+	fieldErrors, ok := err.(validator.ValidationErrors)
+	if !ok || len(fieldErrors) == 0 {
+		return err.Error()
 	}
+	first := fieldErrors[0]
+	return fmt.Sprintf("%s failed validation on tag %q", strings.ToLower(first.Field()), first.Tag())
+}
 
-	switch r.Method {
-	// The rest of the switch logic remains the same, calling getStudentByID, updateStudent, or deleteStudent
-	case "GET":
-		getStudentByID(w, r, id)
-	case "PUT":
-		updateStudent(w, r, id)
-	case "DELETE":
-		deleteStudent(w, r, id)
-	default:
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-	}
+const (
+	defaultStudentListLimit = 50
+	maxStudentListLimit     = 500
+)
+
+// studentListEnvelope is the paginated response shape for GET /api/students.
+type studentListEnvelope struct {
+	Data   []store.Student `json:"data"`
+	Total  int             `json:"total"`
+	Limit  int             `json:"limit"`
+	Offset int             `json:"offset"`
 }
 
-// --- CRUD Functions ---
+// listStudents: GET /api/students?limit=&offset=&sort=&q=&enrolled_after=
+func listStudents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
 
-// READ: Fetch all students
-func getAllStudents(w http.ResponseWriter, r *http.Request) {
-	// db.Query sends the SQL and returns rows
-	rows, err := db.Query("SELECT id, first_name, last_name, email, enrollment_date FROM students ORDER BY id")
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	limit := defaultStudentListLimit
+	if v := query.Get("limit"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+	if limit > maxStudentListLimit {
+		http.Error(w, fmt.Sprintf("limit must not exceed %d", maxStudentListLimit), http.StatusBadRequest)
 		return
 	}
-	defer rows.Close() // ALWAYS close rows to release database resources
 
-	students := []Student{}
-	for rows.Next() {
-		var s Student
-		// rows.Scan reads column values into the struct fields
-		err := rows.Scan(&s.ID, &s.FirstName, &s.LastName, &s.Email, &s.EnrollmentDate)
-		if err != nil {
-			log.Println("Error scanning student:", err)
-			continue
+	offset := 0
+	if v := query.Get("offset"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 0 {
+			http.Error(w, "Invalid offset", http.StatusBadRequest)
+			return
 		}
-		students = append(students, s)
+		offset = parsed
+	}
+
+	result, err := students.List(r.Context(), store.ListParams{
+		Limit:         limit,
+		Offset:        offset,
+		Sort:          query.Get("sort"),
+		Query:         query.Get("q"),
+		EnrolledAfter: query.Get("enrolled_after"),
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	// json.NewEncoder converts the Go slice (array) of structs into a JSON array
-	json.NewEncoder(w).Encode(students)
+	w.Header().Set("X-Total-Count", strconv.Itoa(result.Total))
+	json.NewEncoder(w).Encode(studentListEnvelope{
+		Data:   result.Students,
+		Total:  result.Total,
+		Limit:  limit,
+		Offset: offset,
+	})
 }
 
-// CREATE: Add a new student
+// createStudent: POST /api/students
 func createStudent(w http.ResponseWriter, r *http.Request) {
-	var s Student
+	var s store.Student
 	// json.NewDecoder reads the JSON body from the Next.js request and maps fields to the Student struct
 	if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
-		
+		return
+	}
+	if err := validate.Struct(s); err != nil {
+		if !writeValidationError(w, err) {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		}
 		return
 	}
 
-	// $1, $2, $3 are placeholders for safe SQL injection prevention.
-	// RETURNING id, enrollment_date sends the generated values back immediately.
-	sqlStatement := `INSERT INTO students (first_name, last_name, email) VALUES ($1, $2, $3) RETURNING id, enrollment_date`
+	created, err := students.Create(r.Context(), s)
+	if err != nil {
+		// This handles database errors, like a unique constraint violation on the email field.
+		http.Error(w, fmt.Sprintf("Error creating student: %v", err), http.StatusInternalServerError)
+		return
+	}
 
-	var newID int
-	var enrollmentDate string
+	publishStudentEvent(ws.EventCreated, created)
 
-	// db.QueryRow executes the statement and only expects one row back (the RETURNING values)
-	err := db.QueryRow(sqlStatement, s.FirstName, s.LastName, s.Email).Scan(&newID, &enrollmentDate)
+	w.WriteHeader(http.StatusCreated) // HTTP 201 Created status
+	json.NewEncoder(w).Encode(created)
+}
 
+// getStudent: GET /api/students/{id}
+func getStudent(w http.ResponseWriter, r *http.Request) {
+	id, err := idFromRequest(r)
 	if err != nil {
-		// This handles database errors, like a unique constraint violation on the email field.
-		http.Error(w, fmt.Sprintf("Error creating student: %v", err), http.StatusInternalServerError)
-		fmt.Print(2)
+		http.Error(w, "Invalid student ID format", http.StatusBadRequest)
+		return
+	}
+	if !canAccessStudent(r, id) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
 
+	s, err := students.Get(r.Context(), id)
+	if err == store.ErrNotFound {
+		http.Error(w, "Student not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	s.ID = newID
-	s.EnrollmentDate = enrollmentDate
-	w.WriteHeader(http.StatusCreated) // HTTP 201 Created status
 	json.NewEncoder(w).Encode(s)
 }
 
-// DELETE: Remove a student
-func deleteStudent(w http.ResponseWriter, r *http.Request, id int) {
-	// db.Exec executes a command that doesn't return rows (like DELETE)
-	result, err := db.Exec("DELETE FROM students WHERE id = $1", id)
+// updateStudent: PUT /api/students/{id}
+func updateStudent(w http.ResponseWriter, r *http.Request) {
+	id, err := idFromRequest(r)
 	if err != nil {
+		http.Error(w, "Invalid student ID format", http.StatusBadRequest)
+		return
+	}
+
+	var s store.Student
+	if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := validate.Struct(s); err != nil {
+		if !writeValidationError(w, err) {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		}
+		return
+	}
+
+	updated, err := students.Update(r.Context(), id, s)
+	if err == store.ErrNotFound {
+		http.Error(w, "Student not found", http.StatusNotFound)
+		return
+	} else if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
+	publishStudentEvent(ws.EventUpdated, updated)
+
+	json.NewEncoder(w).Encode(updated)
+}
+
+// deleteStudent: DELETE /api/students/{id}
+func deleteStudent(w http.ResponseWriter, r *http.Request) {
+	id, err := idFromRequest(r)
+	if err != nil {
+		http.Error(w, "Invalid student ID format", http.StatusBadRequest)
+		return
+	}
+
+	if err := students.Delete(r.Context(), id); err == store.ErrNotFound {
 		http.Error(w, "Student not found", http.StatusNotFound)
 		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
+	publishStudentEvent(ws.EventDeleted, store.Student{ID: id})
+
 	w.WriteHeader(http.StatusNoContent) // HTTP 204 No Content for a successful deletion
 }
 
-// (The updateStudent and getStudentByID functions are similar structural patterns to the above,
-// using UPDATE and SELECT with WHERE clauses respectively.)
-func getStudentByID(w http.ResponseWriter, r *http.Request, id int) {
-	var s Student
-	err := db.QueryRow("SELECT id, first_name, last_name, email, enrollment_date FROM students WHERE id = $1", id).
-		Scan(&s.ID, &s.FirstName, &s.LastName, &s.Email, &s.EnrollmentDate)
+// publishStudentEvent notifies every instance of this service (including
+// this one, via its own ws.ListenNotify loop started in main) of a roster
+// mutation, so WebSocket subscribers everywhere see it exactly once.
+func publishStudentEvent(eventType ws.EventType, s store.Student) {
+	event := ws.Event{Type: eventType, Student: s}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Println("Failed to marshal student event:", err)
+		return
+	}
+	if _, err := db.Exec("SELECT pg_notify($1, $2)", studentEventsChannel, string(payload)); err != nil {
+		log.Println("Failed to publish student event notification:", err)
+	}
+}
+
+// --- Auth Handlers ---
+
+type loginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type loginResponse struct {
+	Token string    `json:"token"`
+	Role  auth.Role `json:"role"`
+}
+
+// POST /api/auth/login: validates credentials and issues a signed JWT.
+func loginHandler(w http.ResponseWriter, r *http.Request) {
+	var req loginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
 
+	var userID int
+	var passwordHash string
+	var role auth.Role
+	var studentID *int
+	err := db.QueryRow("SELECT id, password_hash, role, student_id FROM users WHERE email = $1", req.Email).
+		Scan(&userID, &passwordHash, &role, &studentID)
 	if err == sql.ErrNoRows {
-		http.Error(w, "Student not found", http.StatusNotFound)
+		http.Error(w, "Invalid email or password", http.StatusUnauthorized)
 		return
 	} else if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	json.NewEncoder(w).Encode(s)
+	if err := auth.CheckPassword(passwordHash, req.Password); err != nil {
+		http.Error(w, "Invalid email or password", http.StatusUnauthorized)
+		return
+	}
+
+	token, err := auth.GenerateToken(userID, req.Email, role, studentID)
+	if err != nil {
+		http.Error(w, "Failed to issue token", http.StatusInternalServerError)
+		return
+	}
+
+	json.NewEncoder(w).Encode(loginResponse{Token: token, Role: role})
 }
 
-func updateStudent(w http.ResponseWriter, r *http.Request, id int) {
-	var s Student
-	if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+// POST /api/auth/logout: adds the presented token to the server-side blocklist.
+func logoutHandler(w http.ResponseWriter, r *http.Request) {
+	tokenString := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	auth.Invalidate(tokenString, time.Now().Add(24*time.Hour))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type registerRequest struct {
+	Email     string    `json:"email" validate:"required,email"`
+	Password  string    `json:"password" validate:"required"`
+	Role      auth.Role `json:"role" validate:"required,oneof=admin instructor student"`
+	StudentID *int      `json:"student_id,omitempty"`
+}
+
+// POST /api/auth/register: admin-only account creation.
+func registerHandler(w http.ResponseWriter, r *http.Request) {
+	var req registerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
+	if err := validate.Struct(req); err != nil {
+		if !writeValidationError(w, err) {
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		}
+		return
+	}
 
-	result, err := db.Exec("UPDATE students SET first_name = $1, last_name = $2, email = $3 WHERE id = $4",
-		s.FirstName, s.LastName, s.Email, id)
-
+	hash, err := auth.HashPassword(req.Password)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, "Failed to hash password", http.StatusInternalServerError)
 		return
 	}
 
-	rowsAffected, _ := result.RowsAffected()
-	if rowsAffected == 0 {
-		http.Error(w, "Student not found", http.StatusNotFound)
+	var newID int
+	err = db.QueryRow(
+		"INSERT INTO users (email, password_hash, role, student_id) VALUES ($1, $2, $3, $4) RETURNING id",
+		req.Email, hash, req.Role, req.StudentID,
+	).Scan(&newID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Error creating user: %v", err), http.StatusInternalServerError)
 		return
 	}
 
-	s.ID = id
-	json.NewEncoder(w).Encode(s)
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(map[string]interface{}{"id": newID, "email": req.Email, "role": req.Role})
 }
@@ -0,0 +1,8 @@
+// Package migrations embeds the versioned SQL migrations applied on startup
+// via golang-migrate, so the binary carries its schema with it.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,64 @@
+package store
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// Date wraps time.Time so enrollment dates marshal to/from JSON as
+// YYYY-MM-DD instead of a full RFC3339 timestamp, while still scanning
+// directly from a Postgres DATE column.
+type Date struct {
+	time.Time
+}
+
+// MarshalJSON writes the date as "YYYY-MM-DD".
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.Time.IsZero() {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(d.Time.Format(dateLayout))
+}
+
+// UnmarshalJSON parses a "YYYY-MM-DD" string.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		d.Time = time.Time{}
+		return nil
+	}
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return fmt.Errorf("enrollment_date must be in YYYY-MM-DD format: %w", err)
+	}
+	d.Time = t
+	return nil
+}
+
+// Scan implements sql.Scanner so Date can be read from a DATE column.
+func (d *Date) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	t, ok := value.(time.Time)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into store.Date", value)
+	}
+	d.Time = t
+	return nil
+}
+
+// Value implements driver.Valuer so Date can be written to a DATE column.
+func (d Date) Value() (driver.Value, error) {
+	if d.Time.IsZero() {
+		return nil, nil
+	}
+	return d.Time, nil
+}
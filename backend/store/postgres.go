@@ -0,0 +1,174 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// sortColumns whitelists the columns callers may sort by via ListParams.Sort,
+// so the value is never interpolated directly into SQL.
+var sortColumns = map[string]string{
+	"id":              "id",
+	"first_name":      "first_name",
+	"last_name":       "last_name",
+	"email":           "email",
+	"enrollment_date": "enrollment_date",
+}
+
+// PostgresStore is the StudentStore backed by a *sql.DB.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore wraps an open database connection.
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func buildOrderBy(sort string) string {
+	if sort == "" {
+		return "id ASC"
+	}
+	var clauses []string
+	for _, field := range strings.Split(sort, ",") {
+		direction := "ASC"
+		if strings.HasPrefix(field, "-") {
+			direction = "DESC"
+			field = field[1:]
+		}
+		column, ok := sortColumns[field]
+		if !ok {
+			continue
+		}
+		clauses = append(clauses, column+" "+direction)
+	}
+	if len(clauses) == 0 {
+		return "id ASC"
+	}
+	return strings.Join(clauses, ", ")
+}
+
+// List returns a page of students matching params, plus the total count of
+// matching rows (ignoring Limit/Offset).
+func (s *PostgresStore) List(ctx context.Context, params ListParams) (ListResult, error) {
+	var (
+		conditions []string
+		args       []interface{}
+	)
+	if params.Query != "" {
+		args = append(args, params.Query)
+		conditions = append(conditions, fmt.Sprintf(
+			"to_tsvector('english', first_name || ' ' || last_name || ' ' || email) @@ plainto_tsquery('english', $%d)",
+			len(args)))
+	}
+	if params.EnrolledAfter != "" {
+		args = append(args, params.EnrolledAfter)
+		conditions = append(conditions, fmt.Sprintf("enrollment_date >= $%d", len(args)))
+	}
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM students %s", whereClause)
+	if err := s.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return ListResult{}, err
+	}
+
+	listArgs := append(append([]interface{}{}, args...), params.Limit, params.Offset)
+	listQuery := fmt.Sprintf(
+		"SELECT id, first_name, last_name, email, enrollment_date FROM students %s ORDER BY %s LIMIT $%d OFFSET $%d",
+		whereClause, buildOrderBy(params.Sort), len(listArgs)-1, len(listArgs))
+
+	rows, err := s.db.QueryContext(ctx, listQuery, listArgs...)
+	if err != nil {
+		return ListResult{}, err
+	}
+	defer rows.Close()
+
+	students := []Student{}
+	for rows.Next() {
+		var st Student
+		if err := rows.Scan(&st.ID, &st.FirstName, &st.LastName, &st.Email, &st.EnrollmentDate); err != nil {
+			return ListResult{}, err
+		}
+		students = append(students, st)
+	}
+	if err := rows.Err(); err != nil {
+		return ListResult{}, err
+	}
+
+	return ListResult{Students: students, Total: total}, nil
+}
+
+// Get fetches a single student by id.
+func (s *PostgresStore) Get(ctx context.Context, id int) (Student, error) {
+	var st Student
+	err := s.db.QueryRowContext(ctx,
+		"SELECT id, first_name, last_name, email, enrollment_date FROM students WHERE id = $1", id,
+	).Scan(&st.ID, &st.FirstName, &st.LastName, &st.Email, &st.EnrollmentDate)
+	if err == sql.ErrNoRows {
+		return Student{}, ErrNotFound
+	}
+	return st, err
+}
+
+// Create inserts a new student, returning it with its generated id and
+// default enrollment date.
+func (s *PostgresStore) Create(ctx context.Context, st Student) (Student, error) {
+	const query = `INSERT INTO students (first_name, last_name, email) VALUES ($1, $2, $3) RETURNING id, enrollment_date`
+	err := s.db.QueryRowContext(ctx, query, st.FirstName, st.LastName, st.Email).Scan(&st.ID, &st.EnrollmentDate)
+	return st, err
+}
+
+// Update overwrites the name/email fields of the student with the given id.
+func (s *PostgresStore) Update(ctx context.Context, id int, st Student) (Student, error) {
+	result, err := s.db.ExecContext(ctx,
+		"UPDATE students SET first_name = $1, last_name = $2, email = $3 WHERE id = $4",
+		st.FirstName, st.LastName, st.Email, id)
+	if err != nil {
+		return Student{}, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return Student{}, err
+	}
+	if rowsAffected == 0 {
+		return Student{}, ErrNotFound
+	}
+	st.ID = id
+	return st, nil
+}
+
+// Delete removes the student with the given id.
+func (s *PostgresStore) Delete(ctx context.Context, id int) error {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM students WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Upsert inserts a student or, on email conflict, updates the existing row's
+// name fields. Reports whether the row was newly inserted.
+func (s *PostgresStore) Upsert(ctx context.Context, st Student) (bool, error) {
+	const query = `
+	INSERT INTO students (first_name, last_name, email)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (email) DO UPDATE SET first_name = EXCLUDED.first_name, last_name = EXCLUDED.last_name
+	RETURNING (xmax = 0) AS inserted`
+
+	var inserted bool
+	err := s.db.QueryRowContext(ctx, query, st.FirstName, st.LastName, st.Email).Scan(&inserted)
+	return inserted, err
+}
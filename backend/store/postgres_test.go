@@ -0,0 +1,101 @@
+package store
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func newMockStore(t *testing.T) (*PostgresStore, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return NewPostgresStore(db), mock
+}
+
+func TestBuildOrderBy(t *testing.T) {
+	tests := []struct {
+		name string
+		sort string
+		want string
+	}{
+		{"empty defaults to id ASC", "", "id ASC"},
+		{"single ascending column", "first_name", "first_name ASC"},
+		{"dash prefix means descending", "-last_name", "last_name DESC"},
+		{"multiple columns", "last_name,-email", "last_name ASC, email DESC"},
+		{"unknown column is dropped", "nickname", "id ASC"},
+		{"unknown column mixed with known", "nickname,-id", "id DESC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := buildOrderBy(tt.sort); got != tt.want {
+				t.Errorf("buildOrderBy(%q) = %q, want %q", tt.sort, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPostgresStoreList_DefaultParams(t *testing.T) {
+	s, mock := newMockStore(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM students")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "email", "enrollment_date"}).
+		AddRow(1, "Ada", "Lovelace", "ada@example.com", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)).
+		AddRow(2, "Alan", "Turing", "alan@example.com", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM students  ORDER BY id ASC LIMIT $1 OFFSET $2")).
+		WithArgs(50, 0).
+		WillReturnRows(rows)
+
+	result, err := s.List(context.Background(), ListParams{Limit: 50, Offset: 0})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if result.Total != 2 {
+		t.Errorf("Total = %d, want 2", result.Total)
+	}
+	if len(result.Students) != 2 {
+		t.Fatalf("len(Students) = %d, want 2", len(result.Students))
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresStoreList_SearchAndEnrolledAfterFilters(t *testing.T) {
+	s, mock := newMockStore(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM students WHERE to_tsvector")).
+		WithArgs("ada", "2024-01-01").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	rows := sqlmock.NewRows([]string{"id", "first_name", "last_name", "email", "enrollment_date"}).
+		AddRow(1, "Ada", "Lovelace", "ada@example.com", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	mock.ExpectQuery(regexp.QuoteMeta("ORDER BY last_name DESC LIMIT $3 OFFSET $4")).
+		WithArgs("ada", "2024-01-01", 10, 5).
+		WillReturnRows(rows)
+
+	result, err := s.List(context.Background(), ListParams{
+		Limit:         10,
+		Offset:        5,
+		Sort:          "-last_name",
+		Query:         "ada",
+		EnrolledAfter: "2024-01-01",
+	})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if result.Total != 1 {
+		t.Errorf("Total = %d, want 1", result.Total)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+// Package store provides the data-access layer for student records,
+// decoupling HTTP handlers from SQL so handlers can be tested against a
+// mock StudentStore.
+package store
+
+import (
+	"context"
+	"errors"
+)
+
+// Student is the domain model for a student record.
+type Student struct {
+	ID             int    `json:"id"`
+	FirstName      string `json:"first_name" validate:"required,max=100"`
+	LastName       string `json:"last_name" validate:"required,max=100"`
+	Email          string `json:"email" validate:"required,email,max=100"`
+	EnrollmentDate Date   `json:"enrollment_date"`
+}
+
+// ErrNotFound is returned by Get/Update/Delete when no row matches the id.
+var ErrNotFound = errors.New("store: student not found")
+
+// ListParams bounds and filters a List call. Sort is a whitelisted,
+// comma-separated column list, optionally "-"-prefixed for descending order.
+type ListParams struct {
+	Limit         int
+	Offset        int
+	Sort          string
+	Query         string // free-text search against name/email
+	EnrolledAfter string // YYYY-MM-DD lower bound, inclusive
+}
+
+// ListResult is a page of students plus the total matching row count.
+type ListResult struct {
+	Students []Student
+	Total    int
+}
+
+// StudentStore is the persistence interface handlers depend on, so it can
+// be swapped for a mock in tests. PostgresStore itself is tested against a
+// DATA-DOG/go-sqlmock database.
+type StudentStore interface {
+	List(ctx context.Context, params ListParams) (ListResult, error)
+	Get(ctx context.Context, id int) (Student, error)
+	Create(ctx context.Context, s Student) (Student, error)
+	Update(ctx context.Context, id int, s Student) (Student, error)
+	Delete(ctx context.Context, id int) error
+	// Upsert inserts a student or, on email conflict, updates the existing
+	// row's name fields. Reports whether the row was newly inserted. The
+	// bulk CSV/XLSX import does not call it; it runs the same upsert inside
+	// its own transaction.
+	Upsert(ctx context.Context, s Student) (inserted bool, err error)
+}
@@ -0,0 +1,65 @@
+package ws
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	pingInterval = 30 * time.Second
+	writeWait    = 10 * time.Second
+)
+
+var upgrader = websocket.Upgrader{
+	// Every origin is accepted: the handshake is not checked against the
+	// CORS_ORIGINS config. Access rests on the bearer token the route requires.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// ServeHTTP upgrades the connection to a WebSocket and streams hub events as
+// JSON frames until the client disconnects, pinging every pingInterval to
+// detect dead connections.
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	events, unsubscribe := h.Subscribe()
+	defer unsubscribe()
+
+	// Drain client reads on their own goroutine so we notice disconnects
+	// (pong replies, close frames) while the main loop is busy writing.
+	go func() {
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				unsubscribe()
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
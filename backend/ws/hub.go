@@ -0,0 +1,65 @@
+// Package ws implements an in-process publish/subscribe hub that fans out
+// student roster change events to WebSocket subscribers.
+package ws
+
+import "sync"
+
+// EventType identifies the kind of roster change being broadcast.
+type EventType string
+
+const (
+	EventCreated EventType = "created"
+	EventUpdated EventType = "updated"
+	EventDeleted EventType = "deleted"
+)
+
+// Event is the payload pushed to every subscriber on a roster change.
+type Event struct {
+	Type    EventType   `json:"type"`
+	Student interface{} `json:"student"`
+}
+
+// Hub fans out events to every currently-subscribed channel.
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[chan Event]struct{}
+}
+
+// NewHub creates an empty Hub.
+func NewHub() *Hub {
+	return &Hub{subscribers: make(map[chan Event]struct{})}
+}
+
+// Publish fans an event out to every subscriber without blocking on slow
+// readers; a subscriber whose buffer is full drops the event rather than
+// stalling the publisher.
+func (h *Hub) Publish(e Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for ch := range h.subscribers {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
+
+// Subscribe registers a new subscriber channel and returns it along with an
+// idempotent unsubscribe function the caller must invoke when done.
+func (h *Hub) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, 16)
+	h.mu.Lock()
+	h.subscribers[ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subscribers, ch)
+			h.mu.Unlock()
+			close(ch)
+		})
+	}
+	return ch, unsubscribe
+}
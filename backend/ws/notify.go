@@ -0,0 +1,39 @@
+package ws
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ListenNotify bridges Postgres LISTEN/NOTIFY on the given channel into hub,
+// so roster events published via pg_notify by any instance of this service,
+// this one included, are fanned out to this instance's WebSocket subscribers.
+// It blocks, so callers should run it in its own goroutine.
+func ListenNotify(connStr, channel string, hub *Hub) {
+	listener := pq.NewListener(connStr, 10*time.Second, time.Minute, func(_ pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Println("ws: pq listener error:", err)
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(channel); err != nil {
+		log.Println("ws: failed to listen on", channel, ":", err)
+		return
+	}
+
+	for n := range listener.Notify {
+		if n == nil {
+			continue // reconnected; Listen() re-subscribes automatically
+		}
+		var event Event
+		if err := json.Unmarshal([]byte(n.Extra), &event); err != nil {
+			log.Println("ws: failed to decode notification payload:", err)
+			continue
+		}
+		hub.Publish(event)
+	}
+}